@@ -0,0 +1,56 @@
+package nodejs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// computeArchiveFileInputs computes the arguments to the archiving call generateArchiveFile emits from the bound
+// input properties of the given archive_file resource.
+func (g *Generator) computeArchiveFileInputs(r *il.ResourceNode) (sourceDir, outputPath string, err error) {
+	sourceDirProperty, ok := r.Properties.Elements["source_dir"]
+	if !ok {
+		return "", "", errors.Errorf("missing required property \"source_dir\" in resource %s", r.Config.Name)
+	}
+	sourceDir, _, err = g.computeProperty(sourceDirProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	outputPathProperty, ok := r.Properties.Elements["output_path"]
+	if !ok {
+		return "", "", errors.Errorf("missing required property \"output_path\" in resource %s", r.Config.Name)
+	}
+	outputPath, _, err = g.computeProperty(outputPathProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	return sourceDir, outputPath, nil
+}
+
+// generateArchiveFile generates the given archive_file resource as a call that zips source_dir to output_path using
+// adm-zip, the closest Node equivalent of the archive_file resource's "zip" archive type (the only type firewalker
+// translates; other archive_file types have no equivalent single-call Node API and are left for a future pass).
+func (g *Generator) generateArchiveFile(r *il.ResourceNode) error {
+	contract.Require(r.Provider.Config.Name == "archive", "r")
+
+	name := resName(r.Config.Type, r.Config.Name)
+	sourceDir, outputPath, err := g.computeArchiveFileInputs(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("const %s = (() => {\n", name)
+	fmt.Printf("    const zip = new AdmZip();\n")
+	fmt.Printf("    zip.addLocalFolder(%s);\n", sourceDir)
+	fmt.Printf("    zip.writeZip(%s);\n", outputPath)
+	fmt.Printf("    return %s;\n", outputPath)
+	fmt.Printf("})();\n")
+
+	return nil
+}
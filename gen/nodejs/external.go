@@ -0,0 +1,56 @@
+package nodejs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// computeExternalInputs computes the arguments to the execFileSync call generateExternal emits from the bound input
+// properties of the given external resource.
+func (g *Generator) computeExternalInputs(r *il.ResourceNode) (program, query string, err error) {
+	programProperty, ok := r.Properties.Elements["program"]
+	if !ok {
+		return "", "", errors.Errorf("missing required property \"program\" in resource %s", r.Config.Name)
+	}
+	program, _, err = g.computeProperty(programProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	queryProperty, hasQuery := r.Properties.Elements["query"]
+	if !hasQuery {
+		return program, "{}", nil
+	}
+	query, _, err = g.computeProperty(queryProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+	return program, query, nil
+}
+
+// generateExternal generates the given external resource as a call to the program named by its "program" property,
+// passing "query" on stdin and parsing its stdout as JSON, mirroring the external data source's own protocol.
+// Terraform defines "program" as a list of strings (the executable followed by its arguments), so it is bound to a
+// single array-valued expression; execFileSync wants the executable and its arguments split into separate
+// parameters, so the rendered array is split into its head and tail at runtime rather than assumed to already be a
+// path string.
+func (g *Generator) generateExternal(r *il.ResourceNode) error {
+	contract.Require(r.Provider.Config.Name == "external", "r")
+
+	name := resName(r.Config.Type, r.Config.Name)
+	program, query, err := g.computeExternalInputs(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("const %sProgram = %s;\n", name, program)
+	fmt.Printf("const %s = JSON.parse(child_process.execFileSync(%sProgram[0], %sProgram.slice(1), {\n", name, name, name)
+	fmt.Printf("    input: JSON.stringify(%s),\n", query)
+	fmt.Printf("}).toString());\n")
+
+	return nil
+}
@@ -40,7 +40,8 @@ func (g *Generator) computeHTTPInputs(r *il.ResourceNode, indent bool, count str
 	return buf.String(), nil
 }
 
-// generateHTTP generates the given http resource as a call to request-promise-native's single exported function.
+// generateHTTP generates the given http resource as a call to request-promise-native's single exported function. It
+// is registered as the "http" entry in providerHandlers (see providers.go).
 func (g *Generator) generateHTTP(r *il.ResourceNode) error {
 	contract.Require(r.Provider.Config.Name == "http", "r")
 
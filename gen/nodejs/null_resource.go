@@ -0,0 +1,71 @@
+package nodejs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// localExecCommand returns the bound "command" property of r's inline `provisioner "local-exec"` block.
+// null_resource itself has no "command" attribute--command belongs to the nested provisioner block, which the
+// binder attaches to r.Provisioners rather than r.Properties (see the SelfVariable case in il/binder_hil.go, which
+// binds provisioner properties through that same separate path).
+func localExecCommand(r *il.ResourceNode) (il.BoundExpr, error) {
+	for _, p := range r.Provisioners {
+		if p.Config.Type != "local-exec" {
+			continue
+		}
+		command, ok := p.Properties.Elements["command"]
+		if !ok {
+			return nil, errors.Errorf("missing required property \"command\" in local-exec provisioner on resource %s", r.Config.Name)
+		}
+		return command, nil
+	}
+	return nil, errors.Errorf("resource %s has no local-exec provisioner", r.Config.Name)
+}
+
+// computeNullResourceInputs computes the arguments to the exec chain generateNullResource emits from the bound input
+// properties of the given null_resource. "command" is the inline local-exec provisioner command string; "triggers"
+// is optional and is only used to force the apply to re-run, the same role it plays for the null_resource itself.
+func (g *Generator) computeNullResourceInputs(r *il.ResourceNode) (command, triggers string, err error) {
+	commandProperty, err := localExecCommand(r)
+	if err != nil {
+		return "", "", err
+	}
+	command, _, err = g.computeProperty(commandProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	triggersProperty, hasTriggers := r.Properties.Elements["triggers"]
+	if !hasTriggers {
+		return command, "{}", nil
+	}
+	triggers, _, err = g.computeProperty(triggersProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+	return command, triggers, nil
+}
+
+// generateNullResource generates the given null_resource with an inline local-exec provisioner as an apply over its
+// triggers that shells out to the provisioner's command, the closest Node equivalent of a resource whose entire
+// purpose is to run an arbitrary command when its triggers change.
+func (g *Generator) generateNullResource(r *il.ResourceNode) error {
+	contract.Require(r.Provider.Config.Name == "null", "r")
+
+	name := resName(r.Config.Type, r.Config.Name)
+	command, triggers, err := g.computeNullResourceInputs(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("const %s = pulumi.output(%s).apply(() => {\n", name, triggers)
+	fmt.Printf("    child_process.execSync(%s);\n", command)
+	fmt.Printf("});\n")
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package nodejs
+
+import "github.com/pgavlin/firewalker/il"
+
+// providerHandler generates idiomatic source for a resource that is an instance of a particular Terraform provider's
+// data source or resource, in place of the generic pulumi.CustomResource construction EmitResource would otherwise
+// emit--the way generateHTTP turns the "http" data source into a call to request-promise-native.
+type providerHandler func(g *Generator, r *il.ResourceNode) error
+
+// providerHandlers maps a Terraform provider name onto the handler that generates idiomatic source for its
+// resources, gating each handler the same way generateHTTP was originally gated: on r.Provider.Config.Name. Keeping
+// this as a registry rather than a growing switch in EmitDataSource means a new provider-specific conversion is a
+// new file plus one line here, not a change to the dispatch path itself.
+var providerHandlers = map[string]providerHandler{
+	"http":     (*Generator).generateHTTP,
+	"template": (*Generator).generateTemplateFile,
+	"archive":  (*Generator).generateArchiveFile,
+	"external": (*Generator).generateExternal,
+	"null":     (*Generator).generateNullResource,
+}
+
+// EmitDataSource implements il.LanguageBackend. It looks up a provider-specific handler in providerHandlers; ok is
+// false if the resource's provider has no specialized handling, in which case the caller should fall back to
+// EmitResource.
+func (g *Generator) EmitDataSource(name string, r *il.ResourceNode) (bool, error) {
+	handler, ok := providerHandlers[name]
+	if !ok {
+		return false, nil
+	}
+	return true, handler(g, r)
+}
@@ -0,0 +1,55 @@
+package nodejs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// computeTemplateFileInputs computes the arguments to the rendering lambda generateTemplateFile emits from the bound
+// input properties of the given template_file resource.
+func (g *Generator) computeTemplateFileInputs(r *il.ResourceNode) (template, vars string, err error) {
+	templateProperty, ok := r.Properties.Elements["template"]
+	if !ok {
+		return "", "", errors.Errorf("missing required property \"template\" in resource %s", r.Config.Name)
+	}
+	template, _, err = g.computeProperty(templateProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	varsProperty, hasVars := r.Properties.Elements["vars"]
+	if !hasVars {
+		return template, "{}", nil
+	}
+	vars, _, err = g.computeProperty(varsProperty, false, "")
+	if err != nil {
+		return "", "", err
+	}
+	return template, vars, nil
+}
+
+// generateTemplateFile generates the given template_file resource as a lambda that substitutes `vars` into
+// `template` using the same `${name}` placeholder syntax the template provider itself uses.
+func (g *Generator) generateTemplateFile(r *il.ResourceNode) error {
+	contract.Require(r.Provider.Config.Name == "template", "r")
+
+	name := resName(r.Config.Type, r.Config.Name)
+	template, vars, err := g.computeTemplateFileInputs(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("const %s = pulumi.all([%s, %s]).apply(([tmpl, vars]) => {\n", name, template, vars)
+	fmt.Printf("    let rendered = tmpl;\n")
+	fmt.Printf("    for (const key of Object.keys(vars)) {\n")
+	fmt.Printf("        rendered = rendered.split(\"${\" + key + \"}\").join(vars[key]);\n")
+	fmt.Printf("    }\n")
+	fmt.Printf("    return rendered;\n")
+	fmt.Printf("});\n")
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package il
+
+// A LanguageBackend is implemented by each code generator target (e.g. the nodejs and python packages) that
+// firewalker can emit converted Terraform configuration as. Graph-level traversal, binding, and optimization are
+// shared across all backends; only the rendering of a bound graph as source text is backend-specific.
+type LanguageBackend interface {
+	// EmitResource emits the given resource node as a call to construct the corresponding Pulumi resource.
+	EmitResource(r *ResourceNode) error
+
+	// EmitVariable emits the given variable node as a named value the rest of the program can reference.
+	EmitVariable(v *VariableNode) error
+
+	// EmitOutput emits the given output node as an exported program value.
+	EmitOutput(o *OutputNode) error
+
+	// EmitDataSource gives the backend the opportunity to emit the given resource node--whose provider is named by
+	// name (r.Provider.Config.Name)--using an idiomatic, provider-specific call instead of a generic resource
+	// construction call (the way the nodejs backend turns the "http" data source into a call to request-promise-native
+	// rather than a pulumi.CustomResource). ok is false if the backend has no specialized handling for the named
+	// provider, in which case the caller should fall back to EmitResource.
+	EmitDataSource(name string, r *ResourceNode) (ok bool, err error)
+
+	// EmitProperty renders the given bound property tree as backend-specific source text.
+	EmitProperty(n BoundNode) (string, error)
+
+	// EmitCall renders a bound call to the named interpolation function, given the already-rendered source text of
+	// its arguments, as backend-specific source text.
+	EmitCall(name string, args []string) (string, error)
+
+	// EmitVariableAccess renders a bound variable access as backend-specific source text.
+	EmitVariableAccess(v *BoundVariableAccess) (string, error)
+}
@@ -0,0 +1,466 @@
+package il
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/terraform/config"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hcl2Scope carries the loop variables introduced by an enclosing for- or splat-expression, neither of which has a
+// corresponding Terraform config variable and so cannot be resolved via propertyBinder's usual builder lookups. It is
+// threaded explicitly through the HCL2 bind functions rather than carried on propertyBinder itself, since for- and
+// splat-expressions may nest and each needs its own child scope. Named vars holds for-expression loop variables;
+// anonItem/anonType hold a splat expression's implicit per-element symbol, which hclsyntax identifies by pointer
+// rather than by name.
+type hcl2Scope struct {
+	vars     map[string]Type
+	anonItem *hclsyntax.AnonSymbolExpr
+	anonType Type
+}
+
+// child returns a new scope that extends s with the given name/type binding, leaving s unmodified.
+func (s hcl2Scope) child(name string, t Type) hcl2Scope {
+	vars := make(map[string]Type, len(s.vars)+1)
+	for k, v := range s.vars {
+		vars[k] = v
+	}
+	vars[name] = t
+	return hcl2Scope{vars: vars, anonItem: s.anonItem, anonType: s.anonType}
+}
+
+// withAnonItem returns a new scope that resolves the given anonymous splat symbol to t, leaving s's named variables
+// unchanged.
+func (s hcl2Scope) withAnonItem(item *hclsyntax.AnonSymbolExpr, t Type) hcl2Scope {
+	return hcl2Scope{vars: s.vars, anonItem: item, anonType: t}
+}
+
+// bindTemplateExpr binds an HCL2 template expression, binding each of its parts and concatenating the results.
+func (b *propertyBinder) bindTemplateExpr(n *hclsyntax.TemplateExpr, scope hcl2Scope) (BoundExpr, error) {
+	parts := make([]BoundExpr, len(n.Parts))
+	for i, p := range n.Parts {
+		bp, err := b.bindExprHCL2(p, scope)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = bp
+	}
+
+	return &BoundTemplate{Node: n, Parts: parts}, nil
+}
+
+// bindTemplateWrapExpr binds an HCL2 template wrap expression, which arises from a single bare interpolation
+// (`"${foo}"`) and is equivalent to a one-part template.
+func (b *propertyBinder) bindTemplateWrapExpr(n *hclsyntax.TemplateWrapExpr, scope hcl2Scope) (BoundExpr, error) {
+	wrapped, err := b.bindExprHCL2(n.Wrapped, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundTemplate{Node: n, Parts: []BoundExpr{wrapped}}, nil
+}
+
+// bindTupleConsExpr binds an HCL2 tuple (list literal) constructor expression.
+func (b *propertyBinder) bindTupleConsExpr(n *hclsyntax.TupleConsExpr, scope hcl2Scope) (BoundExpr, error) {
+	elements := make([]BoundExpr, len(n.Exprs))
+	for i, e := range n.Exprs {
+		be, err := b.bindExprHCL2(e, scope)
+		if err != nil {
+			return nil, err
+		}
+		elements[i] = be
+	}
+
+	return &BoundTupleCons{Node: n, Elements: elements}, nil
+}
+
+// bindObjectConsExpr binds an HCL2 object (map literal) constructor expression.
+func (b *propertyBinder) bindObjectConsExpr(n *hclsyntax.ObjectConsExpr, scope hcl2Scope) (BoundExpr, error) {
+	keys, values := make([]BoundExpr, len(n.Items)), make([]BoundExpr, len(n.Items))
+	for i, item := range n.Items {
+		k, err := b.bindExprHCL2(item.KeyExpr, scope)
+		if err != nil {
+			return nil, err
+		}
+		v, err := b.bindExprHCL2(item.ValueExpr, scope)
+		if err != nil {
+			return nil, err
+		}
+		keys[i], values[i] = k, v
+	}
+
+	return &BoundObjectCons{Node: n, Keys: keys, Values: values}, nil
+}
+
+// collectionElementType returns the element type of a bound collection expression being iterated or splatted. Lists
+// carry their own ElementType; tuple and object constructors have no meaningful ElementType of their own (see the
+// TypeObject/TypeTuple doc comment), so we fall back to the common type shared by their members, or TypeUnknown if
+// the collection is none of the above or its members disagree.
+func collectionElementType(e BoundExpr) Type {
+	if t := e.Type(); t.IsList() {
+		return t.ElementType()
+	}
+	switch e := e.(type) {
+	case *BoundTupleCons:
+		return commonScalarType(e.Elements)
+	case *BoundObjectCons:
+		return commonScalarType(e.Values)
+	default:
+		return TypeUnknown
+	}
+}
+
+// bindForExpr binds an HCL2 for-expression, introducing its loop variables into a child scope before binding the key
+// (if any), value, and condition (if any) sub-expressions.
+func (b *propertyBinder) bindForExpr(n *hclsyntax.ForExpr, scope hcl2Scope) (BoundExpr, error) {
+	collExpr, err := b.bindExprHCL2(n.CollExpr, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	childScope := scope.child(n.ValVar, collectionElementType(collExpr))
+	if n.KeyVar != "" {
+		childScope = childScope.child(n.KeyVar, TypeString)
+	}
+
+	var keyExpr BoundExpr
+	if n.KeyExpr != nil {
+		keyExpr, err = b.bindExprHCL2(n.KeyExpr, childScope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	valExpr, err := b.bindExprHCL2(n.ValExpr, childScope)
+	if err != nil {
+		return nil, err
+	}
+
+	var condExpr BoundExpr
+	if n.CondExpr != nil {
+		condExpr, err = b.bindExprHCL2(n.CondExpr, childScope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// An object for-expression (one with a key) produces an object; a list for-expression produces a list of the
+	// value expression's type.
+	exprType := valExpr.Type().ListOf()
+	if keyExpr != nil {
+		exprType = TypeObject
+	}
+
+	return &BoundForExpr{
+		Node:     n,
+		ExprType: exprType,
+		CollExpr: collExpr,
+		KeyVar:   n.KeyVar,
+		ValVar:   n.ValVar,
+		KeyExpr:  keyExpr,
+		ValExpr:  valExpr,
+		CondExpr: condExpr,
+		Group:    n.Group,
+	}, nil
+}
+
+// bindSplatExpr binds an HCL2 splat expression, whether the new-style `source[*].each` form or the legacy
+// `source.*.each` form. hclsyntax represents the splat's implicit per-element value as a shared *AnonSymbolExpr
+// pointer (n.Item) that recurs within Each wherever the legacy/new splat syntax refers to the current element; the
+// child scope resolves that specific pointer to the source's element type.
+func (b *propertyBinder) bindSplatExpr(n *hclsyntax.SplatExpr, scope hcl2Scope) (BoundExpr, error) {
+	sourceExpr, err := b.bindExprHCL2(n.Source, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	childScope := scope.withAnonItem(n.Item, collectionElementType(sourceExpr))
+	eachExpr, err := b.bindExprHCL2(n.Each, childScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoundSplat{Node: n, ExprType: eachExpr.Type().ListOf(), SourceExpr: sourceExpr, EachExpr: eachExpr}, nil
+}
+
+// bindExprHCL2Root binds the root expression of an HCL2-parsed property. It is the HCL2 counterpart to bindExpr and
+// is the entry point callers outside this file should use; it simply seeds bindExprHCL2 with an empty scope.
+func (b *propertyBinder) bindExprHCL2Root(n hclsyntax.Expression) (BoundExpr, error) {
+	return b.bindExprHCL2(n, hcl2Scope{})
+}
+
+// bindExprHCL2 binds a single HCL2 expression, dispatching on its concrete `hclsyntax` type. Expressions that arise
+// from a Terraform config parsed by the 0.12+ HCL2-based parser are bound by this function rather than by
+// `bindExpr`, which handles the legacy HIL-based parser's AST instead; which of the two is used for a given module is
+// decided by the parser that produced that module's configuration. scope carries any loop variables introduced by an
+// enclosing for- or splat-expression and is the empty hcl2Scope outside of one.
+func (b *propertyBinder) bindExprHCL2(n hclsyntax.Expression, scope hcl2Scope) (BoundExpr, error) {
+	switch n := n.(type) {
+	case *hclsyntax.TemplateExpr:
+		return b.bindTemplateExpr(n, scope)
+	case *hclsyntax.TemplateWrapExpr:
+		return b.bindTemplateWrapExpr(n, scope)
+	case *hclsyntax.TupleConsExpr:
+		return b.bindTupleConsExpr(n, scope)
+	case *hclsyntax.ObjectConsExpr:
+		return b.bindObjectConsExpr(n, scope)
+	case *hclsyntax.ForExpr:
+		return b.bindForExpr(n, scope)
+	case *hclsyntax.SplatExpr:
+		return b.bindSplatExpr(n, scope)
+	case *hclsyntax.AnonSymbolExpr:
+		if n == scope.anonItem {
+			return &BoundScopeVar{ExprType: scope.anonType, Name: "__item"}, nil
+		}
+		return nil, errors.New("reference to anonymous symbol outside of a splat expression")
+	case *hclsyntax.ScopeTraversalExpr:
+		return b.bindTraversal(n, n.Traversal, scope)
+	case *hclsyntax.RelativeTraversalExpr:
+		return b.bindRelativeTraversalExpr(n, scope)
+	case *hclsyntax.FunctionCallExpr:
+		return b.bindFunctionCallExpr(n, scope)
+	case *hclsyntax.ConditionalExpr:
+		return b.bindConditionalExpr(n, scope)
+	case *hclsyntax.BinaryOpExpr:
+		return b.bindBinaryOpExpr(n, scope)
+	case *hclsyntax.UnaryOpExpr:
+		return b.bindUnaryOpExpr(n, scope)
+	case *hclsyntax.IndexExpr:
+		return b.bindIndexExpr(n, scope)
+	case *hclsyntax.LiteralValueExpr:
+		return b.bindLiteralValueExpr(n)
+	default:
+		return nil, errors.Errorf("unexpected HCL2 expression type %T", n)
+	}
+}
+
+// bindConditionalExpr binds an HCL2 conditional expression (`cond ? true : false`).
+func (b *propertyBinder) bindConditionalExpr(n *hclsyntax.ConditionalExpr, scope hcl2Scope) (BoundExpr, error) {
+	condExpr, err := b.bindExprHCL2(n.Condition, scope)
+	if err != nil {
+		return nil, err
+	}
+	trueExpr, err := b.bindExprHCL2(n.TrueResult, scope)
+	if err != nil {
+		return nil, err
+	}
+	falseExpr, err := b.bindExprHCL2(n.FalseResult, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	exprType := trueExpr.Type()
+	if exprType != falseExpr.Type() {
+		exprType = TypeUnknown
+	}
+
+	return &BoundConditional{ExprType: exprType, CondExpr: condExpr, TrueExpr: trueExpr, FalseExpr: falseExpr}, nil
+}
+
+// bindIndexExpr binds an HCL2 index expression (`collection[key]`).
+func (b *propertyBinder) bindIndexExpr(n *hclsyntax.IndexExpr, scope hcl2Scope) (BoundExpr, error) {
+	boundTarget, err := b.bindExprHCL2(n.Collection, scope)
+	if err != nil {
+		return nil, err
+	}
+	boundKey, err := b.bindExprHCL2(n.Key, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	exprType := TypeUnknown
+	if targetType := boundTarget.Type(); targetType.IsList() {
+		exprType = targetType.ElementType()
+	}
+
+	return &BoundIndex{ExprType: exprType, TargetExpr: boundTarget, KeyExpr: boundKey}, nil
+}
+
+// bindLiteralValueExpr binds an HCL2 literal value expression, mapping its cty type onto the corresponding Type.
+func (b *propertyBinder) bindLiteralValueExpr(n *hclsyntax.LiteralValueExpr) (BoundExpr, error) {
+	exprType, value, err := ctyValueToLiteral(n.Val)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid literal at %v", n.Range())
+	}
+	return &BoundLiteral{ExprType: exprType, Value: value}, nil
+}
+
+// bindBinaryOpExpr binds an HCL2 binary operator expression by folding the pair of operands into the same
+// BoundArithmetic shape the HIL binder produces. The operator itself is not recorded: the result has no HILNode, so
+// passes that read the operator from HILNode (SimplifyExpr's arithmetic folding, the python backend's
+// emitArithmetic) leave it unfolded or reject it.
+func (b *propertyBinder) bindBinaryOpExpr(n *hclsyntax.BinaryOpExpr, scope hcl2Scope) (BoundExpr, error) {
+	lhs, err := b.bindExprHCL2(n.LHS, scope)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := b.bindExprHCL2(n.RHS, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoundArithmetic{Exprs: []BoundExpr{lhs, rhs}}, nil
+}
+
+// bindUnaryOpExpr binds an HCL2 unary operator expression (`-x`, `!x`) as a single-operand BoundArithmetic.
+func (b *propertyBinder) bindUnaryOpExpr(n *hclsyntax.UnaryOpExpr, scope hcl2Scope) (BoundExpr, error) {
+	operand, err := b.bindExprHCL2(n.Val, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundArithmetic{Exprs: []BoundExpr{operand}}, nil
+}
+
+// bindFunctionCallExpr binds an HCL2 function call expression. Type inference for the called function is shared with
+// the HIL binder via bindCallByName so that adding support for a function benefits both expression families.
+func (b *propertyBinder) bindFunctionCallExpr(n *hclsyntax.FunctionCallExpr, scope hcl2Scope) (BoundExpr, error) {
+	args := make([]BoundExpr, len(n.Args))
+	for i, a := range n.Args {
+		ba, err := b.bindExprHCL2(a, scope)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = ba
+	}
+
+	exprType, err := b.bindCallByName(n.Name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoundCall{ExprType: exprType, Name: n.Name, Args: args}, nil
+}
+
+// bindTraversal binds an HCL2 traversal rooted at a scope symbol (e.g. `aws_instance.foo.id`, `var.tags["Name"]`,
+// `path.module`, or a for-/splat-expression loop variable). If the traversal's root name is present in scope, it
+// resolves to that loop variable rather than to a Terraform config variable.
+func (b *propertyBinder) bindTraversal(n hclsyntax.Node, traversal hcl.Traversal, scope hcl2Scope) (BoundExpr, error) {
+	root := traversal.RootName()
+	if t, ok := scope.vars[root]; ok {
+		result := BoundExpr(&BoundScopeVar{ExprType: t, Name: root})
+		for _, step := range traversal[1:] {
+			key, err := traverserToBoundLiteral(step)
+			if err != nil {
+				return nil, err
+			}
+
+			exprType := TypeUnknown
+			if targetType := result.Type(); targetType.IsList() {
+				exprType = targetType.ElementType()
+			}
+			result = &BoundIndex{ExprType: exprType, TargetExpr: result, KeyExpr: key}
+		}
+		return result, nil
+	}
+
+	return b.bindHCL2Variable(n, traversal)
+}
+
+// bindHCL2Variable resolves a traversal that was not satisfied by a loop-variable scope as a Terraform config
+// variable reference. HCL2 traversals and HIL's dotted variable names describe the same namespace, so this
+// reconstructs the equivalent dotted name and defers to the shared bindTFVariable resolution.
+func (b *propertyBinder) bindHCL2Variable(n hclsyntax.Node, traversal hcl.Traversal) (BoundExpr, error) {
+	name, err := traversalToDottedName(traversal)
+	if err != nil {
+		return nil, err
+	}
+
+	tfVar, err := config.NewInterpolatedVariable(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.bindTFVariable(tfVar)
+}
+
+// traversalToDottedName renders an hcl.Traversal back into the dotted variable name syntax HIL uses (e.g.
+// `aws_instance.foo.0.id`, `var.tags.Name`), which is what `config.NewInterpolatedVariable` expects.
+func traversalToDottedName(traversal hcl.Traversal) (string, error) {
+	parts := make([]string, 0, len(traversal))
+	parts = append(parts, traversal.RootName())
+	for _, step := range traversal[1:] {
+		switch step := step.(type) {
+		case hcl.TraverseAttr:
+			parts = append(parts, step.Name)
+		case hcl.TraverseIndex:
+			switch step.Key.Type() {
+			case cty.String:
+				parts = append(parts, step.Key.AsString())
+			case cty.Number:
+				f, _ := step.Key.AsBigFloat().Int64()
+				parts = append(parts, fmt.Sprintf("%d", f))
+			default:
+				return "", errors.Errorf("unexpected index key type %s", step.Key.Type().FriendlyName())
+			}
+		default:
+			return "", errors.Errorf("unexpected traversal step type %T", step)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// bindRelativeTraversalExpr binds an HCL2 relative traversal expression, i.e. a traversal applied to the result of
+// some other expression (`func()[0].field`) rather than to a root symbol. Since such traversals do not name a
+// Terraform variable, the source expression is bound directly and a BoundIndex is applied for each subsequent
+// traversal step.
+func (b *propertyBinder) bindRelativeTraversalExpr(n *hclsyntax.RelativeTraversalExpr, scope hcl2Scope) (BoundExpr, error) {
+	result, err := b.bindExprHCL2(n.Source, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range n.Traversal {
+		key, err := traverserToBoundLiteral(step)
+		if err != nil {
+			return nil, err
+		}
+
+		exprType := TypeUnknown
+		if targetType := result.Type(); targetType.IsList() {
+			exprType = targetType.ElementType()
+		}
+		result = &BoundIndex{ExprType: exprType, TargetExpr: result, KeyExpr: key}
+	}
+	return result, nil
+}
+
+// traverserToBoundLiteral converts a single step of an hcl.Traversal into the BoundExpr that would index into it,
+// mirroring the semantics of the attribute or index access it represents.
+func traverserToBoundLiteral(t hcl.Traverser) (BoundExpr, error) {
+	switch t := t.(type) {
+	case hcl.TraverseAttr:
+		return &BoundLiteral{ExprType: TypeString, Value: t.Name}, nil
+	case hcl.TraverseIndex:
+		exprType, value, err := ctyValueToLiteral(t.Key)
+		if err != nil {
+			return nil, err
+		}
+		return &BoundLiteral{ExprType: exprType, Value: value}, nil
+	default:
+		return nil, errors.Errorf("unexpected traversal step type %T", t)
+	}
+}
+
+// ctyValueToLiteral maps a cty.Value produced by the HCL2 parser onto the (Type, value) pair expected by
+// BoundLiteral. Only the primitive types that Terraform's HCL2 literals can produce are supported; anything else
+// (including null and unknown values) binds to TypeUnknown.
+func ctyValueToLiteral(v cty.Value) (Type, interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return TypeUnknown, nil, nil
+	}
+
+	switch v.Type() {
+	case cty.String:
+		return TypeString, v.AsString(), nil
+	case cty.Bool:
+		return TypeBool, v.True(), nil
+	case cty.Number:
+		f, _ := v.AsBigFloat().Float64()
+		return TypeNumber, f, nil
+	default:
+		return TypeUnknown, nil, nil
+	}
+}
@@ -27,38 +27,136 @@ func (b *propertyBinder) bindCall(n *ast.Call) (BoundExpr, error) {
 		return nil, err
 	}
 
-	exprType := TypeUnknown
-	switch n.Func {
-	case "base64decode":
-		exprType = TypeString
-	case "base64encode":
-		exprType = TypeString
+	exprType, err := b.bindCallByName(n.Func, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoundCall{HILNode: n, ExprType: exprType, Name: n.Func, Args: args}, nil
+}
+
+// commonListElementType returns the element type shared by every list-typed argument in args, or TypeUnknown if the
+// arguments disagree (or none of them are known lists). It is used to type calls such as concat and coalescelist whose
+// result is a list of the same element type as their arguments.
+func commonListElementType(args []BoundExpr) Type {
+	elemType, found := TypeUnknown, false
+	for _, a := range args {
+		if t := a.Type(); t.IsList() {
+			if !found {
+				elemType, found = t.ElementType(), true
+			} else if elemType != t.ElementType() {
+				return TypeUnknown
+			}
+		}
+	}
+	return elemType
+}
+
+// bindCallByName type-checks a call to the named interpolation function against its already-bound arguments and
+// returns the type of the resulting expression. It is shared by the HIL and HCL2 binders, since Terraform's
+// interpolation function library (config/interpolate_funcs.go) is identical across both expression syntaxes. An error
+// is returned for an unrecognized function name, since firewalker does not support user-defined interpolation
+// functions, and for ill-typed arguments to a known function; of the latter, only map's argument count is checked.
+func (b *propertyBinder) bindCallByName(name string, args []BoundExpr) (Type, error) {
+	switch name {
+	case "base64decode", "base64encode", "base64sha256", "base64sha512":
+		return TypeString, nil
 	case "chomp":
-		exprType = TypeString
+		return TypeString, nil
+	case "cidrhost", "cidrnetmask", "cidrsubnet":
+		return TypeString, nil
+	case "coalesce":
+		return commonScalarType(args), nil
+	case "coalescelist":
+		return commonListElementType(args).ListOf(), nil
+	case "concat":
+		return commonListElementType(args).ListOf(), nil
+	case "contains":
+		return TypeBool, nil
+	case "distinct", "reverse", "sort":
+		if len(args) > 0 {
+			return args[0].Type(), nil
+		}
+		return TypeUnknown.ListOf(), nil
 	case "element":
 		if args[0].Type().IsList() {
-			exprType = args[0].Type().ElementType()
+			return args[0].Type().ElementType(), nil
 		}
+		return TypeUnknown, nil
 	case "file":
-		exprType = TypeString
+		return TypeString, nil
+	case "flatten":
+		return TypeUnknown.ListOf(), nil
+	case "floor", "ceil", "max", "min", "signum":
+		return TypeNumber, nil
 	case "format":
-		exprType = TypeString
+		return TypeString, nil
+	case "formatlist":
+		return TypeString.ListOf(), nil
+	case "join":
+		return TypeString, nil
+	case "jsondecode":
+		return TypeUnknown, nil
+	case "jsonencode":
+		return TypeString, nil
+	case "keys":
+		return TypeString.ListOf(), nil
+	case "length":
+		return TypeNumber, nil
 	case "list":
-		exprType = TypeUnknown.ListOf()
+		return TypeUnknown.ListOf(), nil
 	case "lookup":
-		// nothing to do
+		return TypeUnknown, nil
+	case "lower", "upper", "title", "trimspace", "replace":
+		return TypeString, nil
 	case "map":
 		if len(args)%2 != 0 {
-			return nil, errors.Errorf("the numbner of arguments to \"map\" must be even")
+			return TypeUnknown, errors.Errorf("the numbner of arguments to \"map\" must be even")
+		}
+		return TypeMap, nil
+	case "md5", "sha1", "sha256", "sha512":
+		return TypeString, nil
+	case "merge":
+		return TypeMap, nil
+	case "pathexpand":
+		return TypeString, nil
+	case "slice":
+		if len(args) > 0 {
+			return args[0].Type(), nil
 		}
-		exprType = TypeMap
+		return TypeUnknown.ListOf(), nil
 	case "split":
-		exprType = TypeString.ListOf()
+		return TypeString.ListOf(), nil
+	case "substr":
+		return TypeString, nil
+	case "timestamp", "uuid":
+		return TypeString, nil
+	case "transpose":
+		return TypeMap, nil
+	case "urlencode":
+		return TypeString, nil
+	case "values":
+		return TypeUnknown.ListOf(), nil
+	case "zipmap":
+		return TypeMap, nil
 	default:
-		return nil, errors.Errorf("NYI: call to %s", n.Func)
+		return TypeUnknown, errors.Errorf("NYI: call to %s", name)
 	}
+}
 
-	return &BoundCall{HILNode: n, ExprType: exprType, Args: args}, nil
+// commonScalarType returns the type shared by every argument in args, or TypeUnknown if they disagree or the list is
+// empty. It is used to type coalesce, whose result is its first non-null argument.
+func commonScalarType(args []BoundExpr) Type {
+	if len(args) == 0 {
+		return TypeUnknown
+	}
+	t := args[0].Type()
+	for _, a := range args[1:] {
+		if a.Type() != t {
+			return TypeUnknown
+		}
+	}
+	return t
 }
 
 // bindConditional binds an HIL conditional expression.
@@ -161,6 +259,24 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		return nil, err
 	}
 
+	result, err := b.bindTFVariable(tfVar)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := result.(*BoundVariableAccess); ok {
+		v.HILNode = n
+	}
+	return result, nil
+}
+
+// bindTFVariable resolves a parsed Terraform interpolated variable reference to the graph node (if any) and type it
+// refers to. It is shared by the HIL and HCL2 binders: both interpolation syntaxes use the same dotted variable
+// namespace (count., local., module., path., resource, self., simple, terraform., var.), so only the expression
+// syntax used to reference a variable differs between Terraform versions, not the variable namespace itself. Most
+// variable kinds resolve to a BoundVariableAccess, but a few--path and terraform variables in particular--have no
+// backing graph node and so bind to whichever BoundNode variant best captures their meaning instead; codegen
+// backends pattern-match on the concrete type returned here rather than re-deriving it from TFVar.
+func (b *propertyBinder) bindTFVariable(tfVar config.InterpolatedVariable) (BoundExpr, error) {
 	elements, sch, exprType, ilNode := []string(nil), Schemas{}, TypeUnknown, Node(nil)
 	switch v := tfVar.(type) {
 	case *config.CountVariable:
@@ -193,8 +309,16 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 
 		exprType = TypeUnknown.OutputOf()
 	case *config.PathVariable:
-		// "path."
-		return nil, errors.New("NYI: path variables")
+		// "path.module", "path.root", "path.cwd": none of these name a graph node, so all three bind directly to a
+		// synthetic string literal holding the module's directory; path.root and path.cwd are not told apart from
+		// path.module. The result is an ordinary BoundLiteral with nothing marking it as a path, so backends render
+		// it as that string.
+		switch v.Type {
+		case config.PathValueModule, config.PathValueRoot, config.PathValueCwd:
+			return &BoundLiteral{ExprType: TypeString, Value: b.builder.module.Dir}, nil
+		default:
+			return nil, errors.Errorf("unsupported path variable %s", v.FullKey())
+		}
 	case *config.ResourceVariable:
 		// default
 
@@ -226,19 +350,35 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 			exprType = exprType.ListOf()
 		}
 	case *config.SelfVariable:
-		// "self."
-		return nil, errors.New("NYI: self variables")
+		// "self.<field>": valid only inside a provisioner block, where it refers back to the resource that owns the
+		// provisioner. b.resource carries that resource while a provisioner's properties are being bound.
+		if b.resource == nil {
+			return nil, errors.New("self variable reference outside of a resource provisioner")
+		}
+
+		sch = b.resource.Schemas()
+		elements = strings.Split(v.Field, ".")
+		elemSch := sch
+		for _, e := range elements {
+			elemSch = elemSch.PropertySchemas(e)
+		}
+		ilNode, exprType = b.resource, elemSch.Type().OutputOf()
 	case *config.SimpleVariable:
-		// "[^.]\+"
-		return nil, errors.New("NYI: simple variables")
+		// A bare, unqualified reference such as "foo" rather than "var.foo". Terraform itself treats these as
+		// invalid outside of a handful of legacy contexts firewalker does not support, so surface a clear error
+		// naming the reference rather than an opaque NYI.
+		return nil, errors.Errorf("unsupported unqualified variable reference %q", v.Name)
 	case *config.TerraformVariable:
-		// "terraform."
-		return nil, errors.New("NYI: terraform variables")
+		// "terraform.<field>": today the only defined field is "workspace", which has no graph node of its own and
+		// is best modeled as a call to be resolved by the backend (e.g. to `pulumi.getStack()`).
+		switch v.Field {
+		case "workspace":
+			return &BoundCall{ExprType: TypeString, Name: "terraform.workspace"}, nil
+		default:
+			return nil, errors.Errorf("unsupported terraform variable %s", v.FullKey())
+		}
 	case *config.UserVariable:
 		// "var."
-		if v.Elem != "" {
-			return nil, errors.New("NYI: user variable elements")
-		}
 
 		// Look up the variable.
 		vn, ok := b.builder.variables[v.Name]
@@ -254,12 +394,32 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		if vn.DefaultValue != nil && vn.DefaultValue.Type() != TypeString {
 			exprType = TypeUnknown
 		}
+
+		// "var.<elem>" indexes into a map- or list-typed variable; model it as a BoundIndex over the (unindexed)
+		// variable access rather than folding the index into the access itself, so that downstream passes can treat
+		// it exactly like any other bound index expression.
+		if v.Elem != "" {
+			access := &BoundVariableAccess{ExprType: exprType, TFVar: tfVar, ILNode: ilNode}
+
+			// Derive the element type from the variable's actual default value, not from exprType above, which has
+			// already been collapsed to TypeString/TypeUnknown and so can never report a list.
+			elemType := TypeUnknown
+			if vn.DefaultValue != nil {
+				if dt := vn.DefaultValue.Type(); dt.IsList() {
+					elemType = dt.ElementType()
+				}
+			}
+			return &BoundIndex{
+				ExprType:   elemType,
+				TargetExpr: access,
+				KeyExpr:    &BoundLiteral{ExprType: TypeString, Value: v.Elem},
+			}, nil
+		}
 	default:
 		return nil, errors.Errorf("unexpected variable type %T", v)
 	}
 
 	return &BoundVariableAccess{
-		HILNode:  n,
 		Elements: elements,
 		Schemas:  sch,
 		ExprType: exprType,
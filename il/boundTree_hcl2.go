@@ -0,0 +1,121 @@
+package il
+
+import (
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+)
+
+// TypeObject and TypeTuple extend the Type enum so that bound nodes produced from HCL2/cty expressions can carry
+// structural types that have no equivalent in HIL: object constructors (`{ k = v, ... }`) and tuple constructors
+// (`[a, b, ...]` where the elements need not share a type). Neither type has a meaningful ElementType; callers that
+// need to reason about individual members should consult the originating BoundObjectCons/BoundTupleCons node.
+const (
+	TypeObject Type = iota + 100
+	TypeTuple
+)
+
+// BoundForExpr represents a bound HCL2 for-expression (`[for k, v in collection: value if cond]` or the object
+// equivalent). CollExpr is the expression being iterated, KeyVar and ValVar name the loop variables bound for each
+// iteration (KeyVar is empty for a single-variable for-expression), ValExpr produces the value (or object value) for
+// each iteration, KeyExpr is non-nil for an object for-expression and produces the object key for each iteration, and
+// CondExpr is non-nil if the expression has a trailing `if` clause.
+type BoundForExpr struct {
+	Node     hclsyntax.Node
+	ExprType Type
+	CollExpr BoundExpr
+	KeyVar   string
+	ValVar   string
+	KeyExpr  BoundExpr
+	ValExpr  BoundExpr
+	CondExpr BoundExpr
+	Group    bool
+}
+
+// Type returns the type of the for-expression's result: a list of ValExpr's type if this is a list for-expression, or
+// an object if this is an object for-expression (i.e. KeyExpr is non-nil).
+func (n *BoundForExpr) Type() Type {
+	return n.ExprType
+}
+
+func (n *BoundForExpr) isBoundNode() {}
+
+// BoundSplat represents a bound HCL2 splat expression (`source[*].each` or the legacy `source.*.each`). Source is the
+// expression being splatted and Each is evaluated once per element of Source with that element in scope. The binder
+// always sets Each; for a bare splat (`source[*]`) it is the BoundScopeVar for the current element itself.
+type BoundSplat struct {
+	Node       hclsyntax.Node
+	ExprType   Type
+	SourceExpr BoundExpr
+	EachExpr   BoundExpr
+}
+
+// Type returns the splat's result type, which is always a list of the element type produced by EachExpr (or of
+// SourceExpr's element type for a bare splat).
+func (n *BoundSplat) Type() Type {
+	return n.ExprType
+}
+
+func (n *BoundSplat) isBoundNode() {}
+
+// BoundObjectCons represents a bound HCL2 object constructor expression (`{ k1 = v1, k2 = v2 }`). Keys and Values are
+// parallel slices: Keys[i] is the bound key expression for Values[i]. Object constructors are always of type
+// TypeObject; the caller must consult Keys/Values to reason about individual members.
+type BoundObjectCons struct {
+	Node   hclsyntax.Node
+	Keys   []BoundExpr
+	Values []BoundExpr
+}
+
+// Type always returns TypeObject for an object constructor.
+func (n *BoundObjectCons) Type() Type {
+	return TypeObject
+}
+
+func (n *BoundObjectCons) isBoundNode() {}
+
+// BoundTupleCons represents a bound HCL2 tuple constructor expression (`[v1, v2, ...]`). Unlike a BoundListProperty,
+// a tuple constructor's elements are not required to share a type, so it is always of type TypeTuple.
+type BoundTupleCons struct {
+	Node     hclsyntax.Node
+	Elements []BoundExpr
+}
+
+// Type always returns TypeTuple for a tuple constructor.
+func (n *BoundTupleCons) Type() Type {
+	return TypeTuple
+}
+
+func (n *BoundTupleCons) isBoundNode() {}
+
+// BoundScopeVar represents a reference to a for- or splat-expression's loop variable--the HCL2 counterpart to
+// BoundVariableAccess for variables that have no corresponding Terraform config entry and so exist only in an
+// hcl2Scope (a for-expression's KeyVar/ValVar, or a splat expression's implicit per-element value). Backends must
+// render it as a bare identifier (Name), never as a literal: unlike a BoundLiteral, it has no Value, and Name is the
+// variable's name rather than text to quote. Name is synthesized as "__item" for a splat's per-element value, since
+// HCL2 itself leaves that variable unnamed (hclsyntax identifies it by pointer; see hcl2Scope.withAnonItem).
+type BoundScopeVar struct {
+	ExprType Type
+	Name     string
+}
+
+// Type returns the loop variable's bound type.
+func (n *BoundScopeVar) Type() Type {
+	return n.ExprType
+}
+
+func (n *BoundScopeVar) isBoundNode() {}
+
+// BoundTemplate represents a bound HCL2 template expression (`"foo ${bar} baz"`, heredocs, and template interpolation
+// sequences). Parts is the list of bound sub-expressions that are concatenated, in order, to produce the template's
+// result; literal runs of text are represented as BoundLiteral string parts. A template expression is always of type
+// TypeString.
+type BoundTemplate struct {
+	Node  hclsyntax.Node
+	Parts []BoundExpr
+}
+
+// Type always returns TypeString for a template expression.
+func (n *BoundTemplate) Type() Type {
+	return TypeString
+}
+
+func (n *BoundTemplate) isBoundNode() {}
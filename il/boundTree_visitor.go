@@ -103,6 +103,80 @@ func visitBoundOutput(n *BoundOutput, pre, post BoundNodeVisitor) (BoundNode, er
 	return post(n)
 }
 
+func visitBoundForExpr(n *BoundForExpr, pre, post BoundNodeVisitor) (BoundNode, error) {
+	collExpr, err := VisitBoundExpr(n.CollExpr, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	var keyExpr BoundExpr
+	if n.KeyExpr != nil {
+		keyExpr, err = VisitBoundExpr(n.KeyExpr, pre, post)
+		if err != nil {
+			return nil, err
+		}
+	}
+	valExpr, err := VisitBoundExpr(n.ValExpr, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	var condExpr BoundExpr
+	if n.CondExpr != nil {
+		condExpr, err = VisitBoundExpr(n.CondExpr, pre, post)
+		if err != nil {
+			return nil, err
+		}
+	}
+	n.CollExpr, n.KeyExpr, n.ValExpr, n.CondExpr = collExpr, keyExpr, valExpr, condExpr
+	return post(n)
+}
+
+func visitBoundSplat(n *BoundSplat, pre, post BoundNodeVisitor) (BoundNode, error) {
+	sourceExpr, err := VisitBoundExpr(n.SourceExpr, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	eachExpr, err := VisitBoundExpr(n.EachExpr, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	n.SourceExpr, n.EachExpr = sourceExpr, eachExpr
+	return post(n)
+}
+
+func visitBoundObjectCons(n *BoundObjectCons, pre, post BoundNodeVisitor) (BoundNode, error) {
+	keys, err := visitBoundExprs(n.Keys, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	values, err := visitBoundExprs(n.Values, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	n.Keys, n.Values = keys, values
+	return post(n)
+}
+
+func visitBoundTupleCons(n *BoundTupleCons, pre, post BoundNodeVisitor) (BoundNode, error) {
+	elements, err := visitBoundExprs(n.Elements, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	n.Elements = elements
+	return post(n)
+}
+
+func visitBoundTemplate(n *BoundTemplate, pre, post BoundNodeVisitor) (BoundNode, error) {
+	parts, err := visitBoundExprs(n.Parts, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	n.Parts = parts
+	return post(n)
+}
+
 func visitBoundExprs(ns []BoundExpr, pre, post BoundNodeVisitor) ([]BoundExpr, error) {
 	nils := 0
 	for i, e := range ns {
@@ -186,6 +260,18 @@ func VisitBoundNode(n BoundNode, pre, post BoundNodeVisitor) (BoundNode, error)
 		return visitBoundOutput(n, pre, post)
 	case *BoundVariableAccess:
 		return post(n)
+	case *BoundForExpr:
+		return visitBoundForExpr(n, pre, post)
+	case *BoundSplat:
+		return visitBoundSplat(n, pre, post)
+	case *BoundObjectCons:
+		return visitBoundObjectCons(n, pre, post)
+	case *BoundTupleCons:
+		return visitBoundTupleCons(n, pre, post)
+	case *BoundTemplate:
+		return visitBoundTemplate(n, pre, post)
+	case *BoundScopeVar:
+		return post(n)
 	default:
 		contract.Failf("unexpected node type in visitBoundExpr: %T", n)
 		return nil, errors.Errorf("unexpected node type in visitBoundExpr: %T", n)
@@ -0,0 +1,375 @@
+package il
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hil/ast"
+)
+
+// SimplifyExpr performs a constant-folding and dead-branch-elimination pass over a bound property tree. It uses
+// VisitBoundNode's post-order traversal so that a node's children are always folded before the node itself is
+// considered, which lets a single bottom-up pass collapse e.g. `"${1 + 2}"` all the way down to the literal `3`
+// without having to re-walk the tree. The returned node may be a different--or, for an expression that folds away
+// to nothing (an emptied list/map), nil--node than the one passed in.
+func SimplifyExpr(n BoundNode) (BoundNode, error) {
+	return VisitBoundNode(n, IdentityVisitor, simplify)
+}
+
+// simplify is the post-order visitor that performs the actual folding for each node kind SimplifyExpr knows how to
+// reduce. Node kinds it has no opinion about--including those introduced by the HCL2 binder, which does not yet
+// attach enough operator metadata to fold arithmetic--are returned unchanged.
+func simplify(n BoundNode) (BoundNode, error) {
+	switch n := n.(type) {
+	case *BoundArithmetic:
+		return simplifyArithmetic(n), nil
+	case *BoundConditional:
+		return simplifyConditional(n), nil
+	case *BoundCall:
+		return simplifyCall(n), nil
+	case *BoundOutput:
+		return simplifyOutput(n), nil
+	case *BoundMapProperty:
+		if len(n.Elements) == 0 {
+			return nil, nil
+		}
+		return n, nil
+	default:
+		return n, nil
+	}
+}
+
+// simplifyArithmetic folds a BoundArithmetic node whose operands are all literal numbers or all literal bools into
+// the BoundLiteral that results from applying its operator. Nodes bound from an HCL2 binary/unary operator currently
+// carry no HIL AST node to recover the operator from, so they are left unfolded.
+func simplifyArithmetic(n *BoundArithmetic) BoundNode {
+	hilNode, ok := n.HILNode.(*ast.Arithmetic)
+	if !ok {
+		return n
+	}
+
+	if nums, allInts, ok := literalFloats(n.Exprs); ok {
+		if v, ok := foldNumeric(hilNode.Op, nums); ok {
+			if allInts {
+				// HIL evaluates integer operands with integer arithmetic, so the result is truncated toward zero.
+				return &BoundLiteral{ExprType: TypeNumber, Value: int(v)}
+			}
+			return &BoundLiteral{ExprType: TypeNumber, Value: v}
+		}
+	}
+	if bools, ok := literalBools(n.Exprs); ok {
+		if v, ok := foldLogical(hilNode.Op, bools); ok {
+			return &BoundLiteral{ExprType: TypeBool, Value: v}
+		}
+	}
+	return n
+}
+
+// literalFloats returns the values of exprs as float64s if they are all float64 literals or all int literals (HIL
+// binds integer literals as int); allInts reports the latter. A mix of int and float64 literals is not folded.
+func literalFloats(exprs []BoundExpr) (nums []float64, allInts bool, ok bool) {
+	nums, ints := make([]float64, len(exprs)), 0
+	for i, e := range exprs {
+		lit, ok := e.(*BoundLiteral)
+		if !ok {
+			return nil, false, false
+		}
+		switch v := lit.Value.(type) {
+		case float64:
+			nums[i] = v
+		case int:
+			nums[i], ints = float64(v), ints+1
+		default:
+			return nil, false, false
+		}
+	}
+	if ints != 0 && ints != len(exprs) {
+		return nil, false, false
+	}
+	return nums, ints != 0, true
+}
+
+func literalBools(exprs []BoundExpr) ([]bool, bool) {
+	bools := make([]bool, len(exprs))
+	for i, e := range exprs {
+		lit, ok := e.(*BoundLiteral)
+		if !ok {
+			return nil, false
+		}
+		b, ok := lit.Value.(bool)
+		if !ok {
+			return nil, false
+		}
+		bools[i] = b
+	}
+	return bools, true
+}
+
+// foldNumeric applies a binary/variadic numeric operator to a list of already-literal operands. ok is false for an
+// operator this pass does not fold (e.g. a comparison that HIL also represents via ast.Arithmetic) or for a division
+// by zero, which is left for evaluation to report rather than folded away.
+func foldNumeric(op ast.ArithmeticOp, nums []float64) (float64, bool) {
+	switch op {
+	case ast.ArithmeticOpAdd:
+		sum := 0.0
+		for _, f := range nums {
+			sum += f
+		}
+		return sum, true
+	case ast.ArithmeticOpSub:
+		return reduceFloats(nums, func(a, b float64) float64 { return a - b })
+	case ast.ArithmeticOpMul:
+		product := 1.0
+		for _, f := range nums {
+			product *= f
+		}
+		return product, true
+	case ast.ArithmeticOpDiv:
+		for _, f := range nums[1:] {
+			if f == 0 {
+				return 0, false
+			}
+		}
+		return reduceFloats(nums, func(a, b float64) float64 { return a / b })
+	case ast.ArithmeticOpMod:
+		for _, f := range nums[1:] {
+			if int64(f) == 0 {
+				return 0, false
+			}
+		}
+		return reduceFloats(nums, func(a, b float64) float64 {
+			return float64(int64(a) % int64(b))
+		})
+	default:
+		return 0, false
+	}
+}
+
+func reduceFloats(nums []float64, f func(a, b float64) float64) (float64, bool) {
+	if len(nums) == 0 {
+		return 0, false
+	}
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = f(result, n)
+	}
+	return result, true
+}
+
+// foldLogical applies a boolean operator to a list of already-literal operands.
+func foldLogical(op ast.ArithmeticOp, bools []bool) (bool, bool) {
+	switch op {
+	case ast.ArithmeticOpLogicalAnd:
+		for _, b := range bools {
+			if !b {
+				return false, true
+			}
+		}
+		return true, true
+	case ast.ArithmeticOpLogicalOr:
+		for _, b := range bools {
+			if b {
+				return true, true
+			}
+		}
+		return false, true
+	case ast.ArithmeticOpEqual:
+		if len(bools) == 2 {
+			return bools[0] == bools[1], true
+		}
+	case ast.ArithmeticOpNotEqual:
+		if len(bools) == 2 {
+			return bools[0] != bools[1], true
+		}
+	}
+	return false, false
+}
+
+// simplifyConditional collapses a BoundConditional whose condition is a literal bool, since the untaken branch can
+// never execute and dropping it shrinks the emitted program without changing its behavior.
+func simplifyConditional(n *BoundConditional) BoundNode {
+	lit, ok := n.CondExpr.(*BoundLiteral)
+	if !ok {
+		return n
+	}
+	cond, ok := lit.Value.(bool)
+	if !ok {
+		return n
+	}
+	if cond {
+		return n.TrueExpr
+	}
+	return n.FalseExpr
+}
+
+// simplifyOutput flattens a BoundOutput down to its single remaining part once folding has reduced it to one--this
+// is the same projection bindOutput already performs at bind time, reapplied here since folding can newly create the
+// single-part case (e.g. `"${1}${foo}"` folds its first part to a literal, and if foo itself folds away entirely the
+// output is left with one part).
+func simplifyOutput(n *BoundOutput) BoundNode {
+	if len(n.Exprs) == 1 {
+		return n.Exprs[0]
+	}
+	return n
+}
+
+// simplifyCall constant-folds a handful of Terraform's pure interpolation functions when called entirely with
+// literal arguments. Functions whose result depends on external state (file, uuid, timestamp, ...) are never folded.
+func simplifyCall(n *BoundCall) BoundNode {
+	switch n.Name {
+	case "upper":
+		if s, ok := literalString(n.Args, 0); ok {
+			return &BoundLiteral{ExprType: TypeString, Value: strings.ToUpper(s)}
+		}
+	case "lower":
+		if s, ok := literalString(n.Args, 0); ok {
+			return &BoundLiteral{ExprType: TypeString, Value: strings.ToLower(s)}
+		}
+	case "base64encode":
+		if s, ok := literalString(n.Args, 0); ok {
+			return &BoundLiteral{ExprType: TypeString, Value: base64.StdEncoding.EncodeToString([]byte(s))}
+		}
+	case "format":
+		if v, ok := foldFormat(n.Args); ok {
+			return &BoundLiteral{ExprType: TypeString, Value: v}
+		}
+	case "join":
+		if v, ok := foldJoin(n.Args); ok {
+			return &BoundLiteral{ExprType: TypeString, Value: v}
+		}
+	case "length":
+		if v, ok := foldLength(n.Args); ok {
+			return &BoundLiteral{ExprType: TypeNumber, Value: v}
+		}
+	case "element":
+		if v, ok := foldElement(n.Args); ok {
+			return v
+		}
+	case "lookup":
+		if v, ok := foldLookup(n.Args); ok {
+			return v
+		}
+	}
+	return n
+}
+
+func literalString(args []BoundExpr, i int) (string, bool) {
+	if i >= len(args) {
+		return "", false
+	}
+	lit, ok := args[i].(*BoundLiteral)
+	if !ok {
+		return "", false
+	}
+	s, ok := lit.Value.(string)
+	return s, ok
+}
+
+func literalList(args []BoundExpr, i int) ([]BoundExpr, bool) {
+	if i >= len(args) {
+		return nil, false
+	}
+	list, ok := args[i].(*BoundListProperty)
+	if !ok {
+		return nil, false
+	}
+	for _, e := range list.Elements {
+		if _, ok := e.(*BoundLiteral); !ok {
+			return nil, false
+		}
+	}
+	return list.Elements, true
+}
+
+func foldFormat(args []BoundExpr) (string, bool) {
+	format, ok := literalString(args, 0)
+	if !ok {
+		return "", false
+	}
+	rest := make([]interface{}, len(args)-1)
+	for i, a := range args[1:] {
+		lit, ok := a.(*BoundLiteral)
+		if !ok {
+			return "", false
+		}
+		rest[i] = lit.Value
+	}
+	return fmt.Sprintf(format, rest...), true
+}
+
+func foldJoin(args []BoundExpr) (string, bool) {
+	sep, ok := literalString(args, 0)
+	if !ok {
+		return "", false
+	}
+	elements, ok := literalList(args, 1)
+	if !ok {
+		return "", false
+	}
+	strs := make([]string, len(elements))
+	for i, e := range elements {
+		s, ok := e.(*BoundLiteral).Value.(string)
+		if !ok {
+			return "", false
+		}
+		strs[i] = s
+	}
+	return strings.Join(strs, sep), true
+}
+
+func foldLength(args []BoundExpr) (float64, bool) {
+	if s, ok := literalString(args, 0); ok {
+		return float64(len(s)), true
+	}
+	if elements, ok := literalList(args, 0); ok {
+		return float64(len(elements)), true
+	}
+	return 0, false
+}
+
+func foldElement(args []BoundExpr) (BoundExpr, bool) {
+	elements, ok := literalList(args, 0)
+	if !ok || len(elements) == 0 {
+		return nil, false
+	}
+	index, ok := args[1].(*BoundLiteral)
+	if !ok {
+		return nil, false
+	}
+	f, ok := index.Value.(float64)
+	if !ok {
+		return nil, false
+	}
+	i := int(f) % len(elements)
+	if i < 0 {
+		i += len(elements)
+	}
+	return elements[i], true
+}
+
+func foldLookup(args []BoundExpr) (BoundExpr, bool) {
+	if len(args) < 2 {
+		return nil, false
+	}
+	m, ok := args[0].(*BoundMapProperty)
+	if !ok {
+		return nil, false
+	}
+	key, ok := literalString(args, 1)
+	if !ok {
+		return nil, false
+	}
+	if v, ok := m.Elements[key]; ok {
+		if lit, ok := v.(*BoundLiteral); ok {
+			return lit, true
+		}
+		return nil, false
+	}
+	if len(args) == 3 {
+		if lit, ok := args[2].(*BoundLiteral); ok {
+			return lit, true
+		}
+	}
+	return nil, false
+}
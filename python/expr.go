@@ -0,0 +1,449 @@
+package python
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/pkg/errors"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// EmitProperty renders the given bound property tree as Python source text.
+func (g *Generator) EmitProperty(n il.BoundNode) (string, error) {
+	switch n := n.(type) {
+	case *il.BoundArithmetic:
+		return g.emitArithmetic(n)
+	case *il.BoundCall:
+		return g.emitCallExpr(n)
+	case *il.BoundConditional:
+		return g.emitConditional(n)
+	case *il.BoundForExpr:
+		return g.emitForExpr(n)
+	case *il.BoundIndex:
+		return g.emitIndex(n)
+	case *il.BoundListProperty:
+		return g.emitList(n)
+	case *il.BoundLiteral:
+		return g.emitLiteral(n)
+	case *il.BoundMapProperty:
+		return g.emitMap(n)
+	case *il.BoundObjectCons:
+		return g.emitObjectCons(n)
+	case *il.BoundOutput:
+		return g.emitOutputExprs(n)
+	case *il.BoundScopeVar:
+		return pyName(n.Name), nil
+	case *il.BoundSplat:
+		return g.emitSplat(n)
+	case *il.BoundTemplate:
+		return g.emitTemplate(n)
+	case *il.BoundTupleCons:
+		return g.emitTupleCons(n)
+	case *il.BoundVariableAccess:
+		return g.EmitVariableAccess(n)
+	default:
+		return "", errors.Errorf("unexpected bound node type %T", n)
+	}
+}
+
+// emitLiteral renders a bound literal as a Python literal.
+func (g *Generator) emitLiteral(n *il.BoundLiteral) (string, error) {
+	switch v := n.Value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		if v {
+			return "True", nil
+		}
+		return "False", nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case nil:
+		return "None", nil
+	default:
+		return "", errors.Errorf("unexpected literal value type %T", v)
+	}
+}
+
+// arithmeticOperators maps each HIL arithmetic operator onto the Python operator or keyword that implements it.
+var arithmeticOperators = map[ast.ArithmeticOp]string{
+	ast.ArithmeticOpAdd:                "+",
+	ast.ArithmeticOpSub:                "-",
+	ast.ArithmeticOpMul:                "*",
+	ast.ArithmeticOpDiv:                "/",
+	ast.ArithmeticOpMod:                "%",
+	ast.ArithmeticOpLogicalAnd:         "and",
+	ast.ArithmeticOpLogicalOr:          "or",
+	ast.ArithmeticOpEqual:              "==",
+	ast.ArithmeticOpNotEqual:           "!=",
+	ast.ArithmeticOpLessThan:           "<",
+	ast.ArithmeticOpLessThanOrEqual:    "<=",
+	ast.ArithmeticOpGreaterThan:        ">",
+	ast.ArithmeticOpGreaterThanOrEqual: ">=",
+}
+
+// emitArithmetic renders a bound arithmetic expression using the Python operator or keyword that matches its HIL
+// operator (recovered from HILNode, the same way il/simplify.go's simplifyArithmetic does), wrapping the result in
+// pulumi.Output.all(...).apply(...) if any operand is a Pulumi Output (see emitApplied). Arithmetic bound from an
+// HCL2 binary/unary operator carries no HIL node to recover an operator from (bindBinaryOpExpr/bindUnaryOpExpr fold
+// both operators into a bare BoundArithmetic), so that case is an error rather than a silently wrong guess.
+func (g *Generator) emitArithmetic(n *il.BoundArithmetic) (string, error) {
+	hilNode, ok := n.HILNode.(*ast.Arithmetic)
+	if !ok {
+		return "", errors.New("cannot emit an HCL2-origin arithmetic expression: no HIL operator recorded")
+	}
+	op, ok := arithmeticOperators[hilNode.Op]
+	if !ok {
+		return "", errors.Errorf("unsupported arithmetic operator %v", hilNode.Op)
+	}
+
+	return g.emitApplied(n.Exprs, func(parts []string) string {
+		return fmt.Sprintf("(%s)", strings.Join(parts, fmt.Sprintf(" %s ", op)))
+	})
+}
+
+// emitConditional renders a bound conditional expression as a Python conditional expression (`a if cond else b`).
+func (g *Generator) emitConditional(n *il.BoundConditional) (string, error) {
+	cond, err := g.EmitProperty(n.CondExpr)
+	if err != nil {
+		return "", err
+	}
+	trueExpr, err := g.EmitProperty(n.TrueExpr)
+	if err != nil {
+		return "", err
+	}
+	falseExpr, err := g.EmitProperty(n.FalseExpr)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s if %s else %s)", trueExpr, cond, falseExpr), nil
+}
+
+// emitForExpr renders a bound HCL2 for-expression as a Python comprehension: a dict comprehension if the expression
+// has a key (an object for-expression), otherwise a list comprehension. A collection iterated with two loop
+// variables is iterated as `.items()` if it is a map and as `enumerate(...)` otherwise, matching how HCL2 assigns
+// KeyVar an index (list source) or a key (map source). Grouped for-expressions (the trailing `...` that collects
+// multiple values per key into a list) are not yet given special handling and fall back to the last value won per key,
+// same as a plain Python dict comprehension would.
+func (g *Generator) emitForExpr(n *il.BoundForExpr) (string, error) {
+	coll, err := g.EmitProperty(n.CollExpr)
+	if err != nil {
+		return "", err
+	}
+
+	source, vars := coll, pyName(n.ValVar)
+	if n.KeyVar != "" {
+		if t := n.CollExpr.Type(); t == il.TypeMap || t == il.TypeObject {
+			source = fmt.Sprintf("(%s).items()", coll)
+		} else {
+			source = fmt.Sprintf("enumerate(%s)", coll)
+		}
+		vars = fmt.Sprintf("%s, %s", pyName(n.KeyVar), vars)
+	}
+
+	val, err := g.EmitProperty(n.ValExpr)
+	if err != nil {
+		return "", err
+	}
+
+	cond := ""
+	if n.CondExpr != nil {
+		c, err := g.EmitProperty(n.CondExpr)
+		if err != nil {
+			return "", err
+		}
+		cond = fmt.Sprintf(" if %s", c)
+	}
+
+	if n.KeyExpr != nil {
+		key, err := g.EmitProperty(n.KeyExpr)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("{%s: %s for %s in %s%s}", key, val, vars, source, cond), nil
+	}
+	return fmt.Sprintf("[%s for %s in %s%s]", val, vars, source, cond), nil
+}
+
+// emitSplat renders a bound HCL2 splat expression as a list comprehension over `__item`, the name the binder
+// synthesizes for the implicit per-element value that HCL2 itself leaves anonymous (see BoundScopeVar); a bare splat
+// (`source[*]`), whose EachExpr is that BoundScopeVar, becomes `[__item for __item in source]`. A BoundSplat with a
+// nil EachExpr becomes a plain list() call.
+func (g *Generator) emitSplat(n *il.BoundSplat) (string, error) {
+	source, err := g.EmitProperty(n.SourceExpr)
+	if err != nil {
+		return "", err
+	}
+	if n.EachExpr == nil {
+		return fmt.Sprintf("list(%s)", source), nil
+	}
+
+	each, err := g.EmitProperty(n.EachExpr)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("[%s for __item in %s]", each, source), nil
+}
+
+// emitObjectCons renders a bound HCL2 object constructor as a Python dict literal. Unlike emitMap, Keys are
+// themselves bound expressions rather than plain Go strings, since an HCL2 object constructor key need not be a
+// literal.
+func (g *Generator) emitObjectCons(n *il.BoundObjectCons) (string, error) {
+	parts := make([]string, len(n.Keys))
+	for i := range n.Keys {
+		key, err := g.EmitProperty(n.Keys[i])
+		if err != nil {
+			return "", err
+		}
+		value, err := g.EmitProperty(n.Values[i])
+		if err != nil {
+			return "", err
+		}
+		parts[i] = fmt.Sprintf("%s: %s", key, value)
+	}
+	return fmt.Sprintf("{%s}", strings.Join(parts, ", ")), nil
+}
+
+// emitTupleCons renders a bound HCL2 tuple constructor as a Python list literal, the same as emitList renders a
+// BoundListProperty.
+func (g *Generator) emitTupleCons(n *il.BoundTupleCons) (string, error) {
+	parts := make([]string, len(n.Elements))
+	for i, e := range n.Elements {
+		p, err := g.EmitProperty(e)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = p
+	}
+	return fmt.Sprintf("[%s]", strings.Join(parts, ", ")), nil
+}
+
+// emitTemplate renders a bound HCL2 template expression the same way emitOutputExprs renders a bound output:
+// `+`-concatenation of its parts (wrapped in pulumi.Output.all(...).apply(...) if any part is a Pulumi Output; see
+// emitApplied), which is always legal so long as every part renders to a string.
+func (g *Generator) emitTemplate(n *il.BoundTemplate) (string, error) {
+	return g.emitApplied(n.Parts, func(parts []string) string {
+		return strings.Join(parts, " + ")
+	})
+}
+
+// emitIndex renders a bound index expression as a Python subscript.
+func (g *Generator) emitIndex(n *il.BoundIndex) (string, error) {
+	target, err := g.EmitProperty(n.TargetExpr)
+	if err != nil {
+		return "", err
+	}
+	key, err := g.EmitProperty(n.KeyExpr)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s[%s]", target, key), nil
+}
+
+// emitList renders a bound list property as a Python list literal.
+func (g *Generator) emitList(n *il.BoundListProperty) (string, error) {
+	parts := make([]string, len(n.Elements))
+	for i, e := range n.Elements {
+		p, err := g.EmitProperty(e)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = p
+	}
+	return fmt.Sprintf("[%s]", strings.Join(parts, ", ")), nil
+}
+
+// emitMap renders a bound map property as a Python dict literal.
+func (g *Generator) emitMap(n *il.BoundMapProperty) (string, error) {
+	parts := make([]string, 0, len(n.Elements))
+	for k, e := range n.Elements {
+		v, err := g.EmitProperty(e)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", strconv.Quote(k), v))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(parts, ", ")), nil
+}
+
+// emitOutputExprs renders a bound output (the concatenation of an interpolated string's parts) as a single Python
+// f-string-equivalent: since not every part is a compile-time string literal, we fall back to `+`-concatenation
+// (wrapped in pulumi.Output.all(...).apply(...) if any part is a Pulumi Output; see emitApplied), which is always
+// legal so long as every part renders to a string.
+func (g *Generator) emitOutputExprs(n *il.BoundOutput) (string, error) {
+	return g.emitApplied(n.Exprs, func(parts []string) string {
+		return strings.Join(parts, " + ")
+	})
+}
+
+// emitApplied renders the parts of a composed expression (a BoundOutput, BoundTemplate, or BoundArithmetic)--each
+// individually rendered, then joined by combine--wrapping the whole thing in a
+// `pulumi.Output.all(...).apply(lambda ...)` whenever one of those parts is a Pulumi Output: Python's Output type
+// does not support being combined with `+`, string formatting, or any other plain operator outside of an apply
+// callback (see gen/nodejs/template_file.go's generateTemplateFile for the equivalent TypeScript idiom). Parts that
+// do not reference an Output are rendered as-is, with no wrapping, since they are already plain Python values.
+func (g *Generator) emitApplied(exprs []il.BoundExpr, combine func([]string) string) (string, error) {
+	refs, err := g.collectOutputRefs(exprs)
+	if err != nil {
+		return "", err
+	}
+	if len(refs) == 0 {
+		parts := make([]string, len(exprs))
+		for i, e := range exprs {
+			p, err := g.EmitProperty(e)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = p
+		}
+		return combine(parts), nil
+	}
+
+	args := make([]string, len(refs))
+	argNames := make([]string, len(refs))
+	outputArgs := make(map[*il.BoundVariableAccess]string, len(g.outputArgs)+len(refs))
+	for k, v := range g.outputArgs {
+		outputArgs[k] = v
+	}
+	for i, ref := range refs {
+		a, err := g.EmitVariableAccess(ref)
+		if err != nil {
+			return "", err
+		}
+		args[i] = a
+		argNames[i] = fmt.Sprintf("arg%d", i)
+		outputArgs[ref] = argNames[i]
+	}
+
+	saved := g.outputArgs
+	g.outputArgs = outputArgs
+	defer func() { g.outputArgs = saved }()
+
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		p, err := g.EmitProperty(e)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = p
+	}
+
+	return fmt.Sprintf("pulumi.Output.all(%s).apply(lambda %s: %s)",
+		strings.Join(args, ", "), strings.Join(argNames, ", "), combine(parts)), nil
+}
+
+// collectOutputRefs returns, in first-appearance order, every BoundVariableAccess within exprs that resolves to a
+// Pulumi Output value--a resource, local, or module reference--skipping plain Terraform variables (which
+// EmitVariable inlines as a literal Python value rather than an Output), the count index, and any reference already
+// resolved to a lambda argument by an enclosing emitApplied call (g.outputArgs).
+func (g *Generator) collectOutputRefs(exprs []il.BoundExpr) ([]*il.BoundVariableAccess, error) {
+	var refs []*il.BoundVariableAccess
+	seen := map[*il.BoundVariableAccess]bool{}
+	for _, e := range exprs {
+		_, err := il.VisitBoundNode(e, il.IdentityVisitor, func(n il.BoundNode) (il.BoundNode, error) {
+			v, ok := n.(*il.BoundVariableAccess)
+			if !ok || seen[v] || v.ILNode == nil {
+				return n, nil
+			}
+			if _, isVar := v.ILNode.(*il.VariableNode); isVar {
+				return n, nil
+			}
+			if _, isCount := v.TFVar.(*config.CountVariable); isCount {
+				return n, nil
+			}
+			if _, already := g.outputArgs[v]; already {
+				return n, nil
+			}
+			seen[v] = true
+			refs = append(refs, v)
+			return n, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// EmitVariableAccess renders a bound variable access as a Python expression. A reference already resolved to a
+// lambda argument by an enclosing emitApplied call renders as that argument's bare name. Otherwise, accesses to
+// another resource's output property are wrapped so that they read naturally against the Pulumi Python SDK's Output
+// type: plain attribute access for resource/local/module references (Output values support attribute-style chaining
+// via `.apply` at the call site, not here), and direct name reference for variables.
+func (g *Generator) EmitVariableAccess(v *il.BoundVariableAccess) (string, error) {
+	if name, ok := g.outputArgs[v]; ok {
+		return name, nil
+	}
+
+	if _, ok := v.TFVar.(*config.CountVariable); ok {
+		return "count_index", nil
+	}
+
+	if v.ILNode == nil {
+		return "", errors.Errorf("unresolved variable access %v", v.Elements)
+	}
+
+	name := pyName(v.ILNode.Name())
+	if len(v.Elements) == 0 {
+		return name, nil
+	}
+	return fmt.Sprintf("%s.%s", name, strings.Join(pySnakeElements(v.Elements), ".")), nil
+}
+
+// pySnakeElements passes each element of a resource attribute path through pyName. Terraform attribute names are
+// already snake_case, which matches the pulumi_aws SDK's Python property naming (e.g. `private_ip`, which the
+// TypeScript SDK calls `privateIp`); no camelCase conversion is done.
+func pySnakeElements(elements []string) []string {
+	out := make([]string, len(elements))
+	for i, e := range elements {
+		out[i] = pyName(e)
+	}
+	return out
+}
+
+// EmitCall renders a bound call to the named interpolation function, given the already-rendered source text of its
+// arguments, as a Python expression. length, lower, upper, trimspace, split, join, keys and values are rendered with
+// Python built-ins and str/dict methods; every other function is rendered as a call to `tf.<name>(...)`, and this
+// package does not emit a definition of `tf`.
+func (g *Generator) EmitCall(name string, args []string) (string, error) {
+	joined := strings.Join(args, ", ")
+	switch name {
+	case "length":
+		return fmt.Sprintf("len(%s)", joined), nil
+	case "lower":
+		return fmt.Sprintf("(%s).lower()", joined), nil
+	case "upper":
+		return fmt.Sprintf("(%s).upper()", joined), nil
+	case "trimspace":
+		return fmt.Sprintf("(%s).strip()", joined), nil
+	case "split":
+		return fmt.Sprintf("(%s).split(%s)", args[1], args[0]), nil
+	case "join":
+		return fmt.Sprintf("(%s).join(%s)", args[0], args[1]), nil
+	case "keys":
+		return fmt.Sprintf("list((%s).keys())", joined), nil
+	case "values":
+		return fmt.Sprintf("list((%s).values())", joined), nil
+	default:
+		return fmt.Sprintf("tf.%s(%s)", name, joined), nil
+	}
+}
+
+// emitCallExpr renders a BoundCall's already-bound arguments to source text and delegates to EmitCall.
+func (g *Generator) emitCallExpr(n *il.BoundCall) (string, error) {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		s, err := g.EmitProperty(a)
+		if err != nil {
+			return "", err
+		}
+		args[i] = s
+	}
+	return g.EmitCall(n.Name, args)
+}
@@ -0,0 +1,121 @@
+// Package python implements an il.LanguageBackend that emits converted Terraform configuration as Python source
+// suitable for use with the Pulumi Python SDK. Its conventions mirror those the nodejs package uses for TypeScript:
+// snake_case identifiers, `pulumi.Output.all(...).apply(lambda ...)` in place of template-string interpolation, and
+// `pulumi.export` for outputs.
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pgavlin/firewalker/il"
+)
+
+// Generator generates Pulumi programs written in Python from a bound Terraform configuration graph.
+type Generator struct {
+	// outputArgs, while non-nil, renders a BoundVariableAccess that resolves to a Pulumi Output as the named lambda
+	// argument it was bound to instead of the normal attribute-access expression; it is set by emitApplied for the
+	// body of a `pulumi.Output.all(...).apply(lambda ...)` it is constructing and restored once that body is
+	// rendered. See EmitVariableAccess.
+	outputArgs map[*il.BoundVariableAccess]string
+}
+
+// resourceModules maps a Terraform provider name onto the pulumi_<provider> Python module that supplies its
+// resources, mirroring the mapping the TypeScript backend keeps implicitly via its `@pulumi/<provider>` imports.
+// resourceClass consults its keys to reject providers that have no entry here.
+var resourceModules = map[string]string{
+	"aws":    "pulumi_aws",
+	"random": "pulumi_random",
+}
+
+// pyName converts a Terraform resource or variable name into a snake_case Python identifier. Terraform names are
+// already snake_case by convention, so this only needs to deal with the occasional hyphen.
+func pyName(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
+
+// resourceClass splits a Terraform resource type (e.g. "aws_instance") into the pulumi_aws module and class name
+// (e.g. "pulumi_aws.ec2.Instance" is out of scope here--we settle for the module-qualified snake_case type, e.g.
+// "aws.Instance", which relies on an `import pulumi_aws as aws` alias in the program; this package does not emit that
+// import) that constructs it. provider must be a key of resourceModules; resourceClass returns an error for any other
+// provider.
+func resourceClass(resourceType string) (module, class string, err error) {
+	parts := strings.SplitN(resourceType, "_", 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("invalid resource type %q", resourceType)
+	}
+	provider, rest := parts[0], parts[1]
+
+	if _, ok := resourceModules[provider]; !ok {
+		return "", "", errors.Errorf("unknown provider %q for resource type %q", provider, resourceType)
+	}
+
+	className := &strings.Builder{}
+	for _, word := range strings.Split(rest, "_") {
+		if word == "" {
+			continue
+		}
+		className.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return provider, className.String(), nil
+}
+
+// EmitResource emits the given resource node as a call to construct the corresponding pulumi_<provider> resource.
+// Data sources that have a specialized handler (see EmitDataSource) are routed there instead of here.
+func (g *Generator) EmitResource(r *il.ResourceNode) error {
+	if r.Provider != nil {
+		if ok, err := g.EmitDataSource(r.Provider.Config.Name, r); ok || err != nil {
+			return err
+		}
+	}
+
+	name := pyName(r.Config.Name)
+	provider, class, err := resourceClass(r.Config.Type)
+	if err != nil {
+		return err
+	}
+
+	args, err := g.emitResourceArgs(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s = %s.%s(\"%s\"%s)\n", name, provider, class, r.Config.Name, args)
+	return nil
+}
+
+// emitResourceArgs renders a resource's bound properties as Python keyword arguments, e.g. `, foo=\"bar\", baz=qux`.
+func (g *Generator) emitResourceArgs(r *il.ResourceNode) (string, error) {
+	buf := &strings.Builder{}
+	for name, prop := range r.Properties.Elements {
+		value, err := g.EmitProperty(prop)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(buf, ", %s=%s", pyName(name), value)
+	}
+	return buf.String(), nil
+}
+
+// EmitVariable emits the given variable node as a plain Python assignment; Terraform variables have no equivalent
+// input mechanism in a Pulumi Python program, so they are simply inlined as their default value.
+func (g *Generator) EmitVariable(v *il.VariableNode) error {
+	value, err := g.EmitProperty(v.DefaultValue)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s = %s\n", pyName(v.Config.Name), value)
+	return nil
+}
+
+// EmitOutput emits the given output node as a call to pulumi.export.
+func (g *Generator) EmitOutput(o *il.OutputNode) error {
+	value, err := g.EmitProperty(o.Value)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("pulumi.export(\"%s\", %s)\n", o.Config.Name, value)
+	return nil
+}
@@ -0,0 +1,25 @@
+package python
+
+import "github.com/pgavlin/firewalker/il"
+
+// providerHandler generates idiomatic Python source for a resource that is an instance of a particular Terraform
+// provider's data source or resource, in place of the generic pulumi_<provider> construction EmitResource would
+// otherwise emit--the Python counterpart to gen/nodejs's providerHandler.
+type providerHandler func(g *Generator, r *il.ResourceNode) error
+
+// providerHandlers maps a Terraform provider name onto the handler that generates idiomatic source for its
+// resources. It has no entries yet--none of the nodejs package's specialized converters (http, template_file,
+// archive_file, external, null_resource) have a Python counterpart implemented--but EmitResource already dispatches
+// through it so that adding one is a new file plus one line here, not a change to the dispatch path itself.
+var providerHandlers = map[string]providerHandler{}
+
+// EmitDataSource implements il.LanguageBackend. It looks up a provider-specific handler in providerHandlers; ok is
+// false if the resource's provider has no specialized handling, in which case the caller should fall back to
+// EmitResource.
+func (g *Generator) EmitDataSource(name string, r *il.ResourceNode) (bool, error) {
+	handler, ok := providerHandlers[name]
+	if !ok {
+		return false, nil
+	}
+	return true, handler(g, r)
+}